@@ -1,6 +1,8 @@
 package pgs
 
 import (
+	"sync"
+
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/golang/protobuf/protoc-gen-go/plugin"
 )
@@ -29,6 +31,13 @@ type graph struct {
 	targets  map[string]File
 	packages map[string]Package
 	entities map[string]Entity
+
+	// seen, when set, is the shared cross-shard resolver used by
+	// mustSeen/add during the concurrent per-file hydration pass in
+	// hydrateFilesConcurrently. It is nil for the top-level graph returned
+	// from ProcessDescriptorsWithOptions and for each sequentially-hydrated
+	// file, preserving the original direct g.entities lookups.
+	seen *seenResolver
 }
 
 func (g *graph) Targets() map[string]File { return g.targets }
@@ -42,7 +51,20 @@ func (g *graph) Lookup(name string) (Entity, bool) {
 
 // ProcessDescriptors converts a CodeGeneratorRequest from protoc into a fully
 // connected AST entity graph. An error is returned if the input is malformed.
+// Any ASTPlugin registered via RegisterASTPlugin is notified as each File,
+// Message, Field and Method is fully hydrated. It is equivalent to calling ProcessDescriptorsWithOptions
+// with the zero Options (sequential hydration).
 func ProcessDescriptors(debug Debugger, req *plugin_go.CodeGeneratorRequest) AST {
+	return ProcessDescriptorsWithOptions(debug, req, Options{})
+}
+
+// ProcessDescriptorsWithOptions is ProcessDescriptors with explicit control
+// over hydration Parallelism. Sequential re-hydration of every dependency on
+// every protoc invocation dominates wall-clock time for large monorepos;
+// setting Parallelism > 1 hydrates each file in req.GetProtoFile()
+// concurrently, merging the results back into the graph in a subsequent
+// serial linking pass.
+func ProcessDescriptorsWithOptions(debug Debugger, req *plugin_go.CodeGeneratorRequest, opts Options) AST {
 	g := &graph{
 		d:        debug,
 		targets:  make(map[string]File, len(req.GetFileToGenerate())),
@@ -54,14 +76,73 @@ func ProcessDescriptors(debug Debugger, req *plugin_go.CodeGeneratorRequest) AST
 		g.targets[f] = nil
 	}
 
-	for _, f := range req.GetProtoFile() {
-		pkg := g.hydratePackage(f)
-		pkg.addFile(g.hydrateFile(pkg, f))
+	protoFiles := req.GetProtoFile()
+
+	// Packages are resolved serially up front (cheap, and g.packages is not
+	// safe for the concurrent writes the per-file pass below would
+	// otherwise need) so every file - whether hydrated sequentially or
+	// concurrently - attaches to the same Package instance.
+	pkgs := make([]Package, len(protoFiles))
+	for i, f := range protoFiles {
+		pkgs[i] = g.hydratePackage(f)
+	}
+
+	files := make([]File, len(protoFiles))
+	if opts.Parallelism > 1 {
+		g.hydrateFilesConcurrently(protoFiles, pkgs, files, opts)
+	} else {
+		for i, f := range protoFiles {
+			files[i] = g.hydrateFile(pkgs[i], f)
+		}
+	}
+
+	// Serial linking pass: attach each hydrated File to its Package and, for
+	// build targets, to g.targets. Entities themselves were already linked
+	// during hydration (directly in the sequential case, or via the
+	// concurrent pass's seenResolver in the parallel case).
+	for i, f := range protoFiles {
+		pkgs[i].addFile(files[i])
+
+		if _, target := g.targets[f.GetName()]; target {
+			g.targets[f.GetName()] = files[i]
+			files[i].(*file).buildTarget = true
+		}
 	}
 
 	return g
 }
 
+// hydrateFilesConcurrently implements the parallel per-file pass described in
+// ProcessDescriptorsWithOptions. Each file is hydrated by its own shard
+// graph so that the shard-local g.entities map needs no locking; cross-file
+// references made via mustSeen are resolved through the shared seenResolver,
+// which blocks a goroutine until the FQN it needs has been hydrated by
+// another shard, regardless of fan-out order. Once every shard completes,
+// its entities are merged into the real graph.entities.
+func (g *graph) hydrateFilesConcurrently(protoFiles []*descriptor.FileDescriptorProto, pkgs []Package, files []File, opts Options) {
+	seen := newSeenResolver()
+	sem := make(chan struct{}, opts.Parallelism)
+
+	var wg sync.WaitGroup
+	for i, f := range protoFiles {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, f *descriptor.FileDescriptorProto) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			shard := &graph{d: g.d, seen: seen, entities: make(map[string]Entity)}
+			files[i] = shard.hydrateFile(pkgs[i], f)
+		}(i, f)
+	}
+	wg.Wait()
+
+	for fqn, e := range seen.entities() {
+		g.entities[fqn] = e
+	}
+}
+
 func (g *graph) hydratePackage(f *descriptor.FileDescriptorProto) Package {
 	lookup := f.GetPackage()
 	if pkg, exists := g.packages[lookup]; exists {
@@ -74,6 +155,10 @@ func (g *graph) hydratePackage(f *descriptor.FileDescriptorProto) Package {
 	return p
 }
 
+// hydrateFile hydrates f into a fully-linked File. buildTarget and Package
+// membership are the caller's responsibility: ProcessDescriptorsWithOptions
+// assigns both in its serial linking pass so they stay race-free when files
+// are hydrated concurrently.
 func (g *graph) hydrateFile(pkg Package, f *descriptor.FileDescriptorProto) File {
 	fl := &file{
 		pkg:  pkg,
@@ -81,10 +166,6 @@ func (g *graph) hydrateFile(pkg Package, f *descriptor.FileDescriptorProto) File
 	}
 	g.add(fl)
 
-	if _, fl.buildTarget = g.targets[f.GetName()]; fl.buildTarget {
-		g.targets[f.GetName()] = fl
-	}
-
 	enums := f.GetEnumType()
 	fl.enums = make([]Enum, 0, len(enums))
 	for _, e := range enums {
@@ -103,20 +184,54 @@ func (g *graph) hydrateFile(pkg Package, f *descriptor.FileDescriptorProto) File
 		fl.addService(g.hydrateService(fl, sd))
 	}
 
+	exts := f.GetExtension()
+	fl.exts = make([]Extension, 0, len(exts))
+	for _, ed := range exts {
+		fl.addExtension(g.hydrateExtension(fl, ed))
+	}
+
 	for _, m := range fl.AllMessages() {
 		for _, me := range m.MapEntries() {
 			for _, fld := range me.Fields() {
 				fld.addType(g.hydrateFieldType(fld))
+				g.notifyHydrateField(fld)
 			}
 		}
 
 		for _, fld := range m.Fields() {
 			fld.addType(g.hydrateFieldType(fld))
+			g.notifyHydrateField(fld)
+		}
+
+		for _, e := range m.Extensions() {
+			g.hydrateExtensionType(e)
+		}
+	}
+
+	// Messages are notified in a second pass so that, when a Message's hook
+	// fires, the fields of its nested Messages have been typed as well.
+	for _, m := range fl.AllMessages() {
+		g.notifyHydrateMessage(m)
+	}
+
+	for _, e := range fl.Extensions() {
+		g.hydrateExtensionType(e)
+	}
+
+	// HTTP rules are hydrated only after every field in the file has a
+	// resolved FieldType: resolveHTTPField inspects the input Message's
+	// field types, which aren't available yet during hydrateService above.
+	for _, s := range fl.Services() {
+		for _, m := range s.Methods() {
+			m.addHTTPRules(g.hydrateHTTPRules(m, m.Descriptor()))
+			g.notifyHydrateMethod(m)
 		}
 	}
 
 	g.hydrateSourceCodeInfo(fl, f)
 
+	g.notifyHydrateFile(fl)
+
 	return fl
 }
 
@@ -182,6 +297,10 @@ func (g *graph) hydrateService(f File, sd *descriptor.ServiceDescriptorProto) Se
 	return s
 }
 
+// hydrateMethod resolves a Method's input and output types. HTTPRules are
+// deliberately left unset here: resolveHTTPField needs the input Message's
+// Fields to already carry a resolved FieldType, which the deferred pass in
+// hydrateFile only guarantees once every field in the file has been typed.
 func (g *graph) hydrateMethod(s Service, md *descriptor.MethodDescriptorProto) Method {
 	m := &method{
 		desc:    md,
@@ -226,10 +345,28 @@ func (g *graph) hydrateMessage(p ParentEntity, md *descriptor.DescriptorProto) M
 		m.addField(fld)
 
 		if idx := fld.Descriptor().OneofIndex; idx != nil {
-			m.oneofs[*idx].addField(fld)
+			o := m.oneofs[*idx]
+			o.addField(fld)
+
+			// protoc represents each proto3 "optional" field as the sole
+			// member of a compiler-generated oneof, purely so presence can
+			// be tracked on the wire. That's an implementation detail, not
+			// a real oneof, so mark the oneof synthetic (the field's
+			// InRealOneOf follows from it): downstream generators care
+			// whether a field participates in an oneof a user actually
+			// wrote.
+			if fd.GetProto3Optional() {
+				o.setSynthetic(true)
+			}
 		}
 	}
 
+	exts := md.GetExtension()
+	m.exts = make([]Extension, 0, len(exts))
+	for _, ed := range exts {
+		m.addExtension(g.hydrateExtension(m, ed))
+	}
+
 	return m
 }
 
@@ -243,6 +380,28 @@ func (g *graph) hydrateField(m Message, fd *descriptor.FieldDescriptorProto) Fie
 	return f
 }
 
+func (g *graph) hydrateExtension(p ParentEntity, fd *descriptor.FieldDescriptorProto) Extension {
+	e := &ext{
+		desc:   fd,
+		parent: p,
+	}
+	g.add(e)
+
+	return e
+}
+
+// hydrateExtensionType resolves an Extension's Extendee and FieldType. This
+// mirrors the deferred field-type pass in hydrateFile: the extendee and any
+// message/enum type referenced by the extension may not have been hydrated
+// yet at the point the Extension itself is added to the graph.
+func (g *graph) hydrateExtensionType(e Extension) {
+	extendee := g.mustSeen(e.Descriptor().GetExtendee()).(Message)
+	e.(*ext).setExtendee(extendee)
+	extendee.addExtendee(e)
+
+	e.addType(g.hydrateFieldType(e))
+}
+
 func (g *graph) hydrateOneOf(m Message, od *descriptor.OneofDescriptorProto) OneOf {
 	o := &oneof{
 		desc: od,
@@ -327,7 +486,22 @@ func (g *graph) hydrateMapFieldType(r *repT, m Message) FieldType {
 	return mt
 }
 
+// mustSeen looks up the already-hydrated Entity named fqn. Outside of the
+// concurrent hydration pass, every reference is expected to have already
+// been added to the graph in hydration order, so a miss is fatal. During
+// the concurrent pass (g.seen != nil), references may legitimately still be
+// hydrating in another shard, so the lookup blocks on g.seen instead, up to
+// mustSeenTimeout, before reporting the same fatal error.
 func (g *graph) mustSeen(fqn string) Entity {
+	if g.seen != nil {
+		e, ok := g.seen.get(fqn)
+		if !ok {
+			g.d.Failf("expected entity %q has not been hydrated (timed out waiting during concurrent hydration)", fqn)
+			return nil
+		}
+		return e
+	}
+
 	if existing, seen := g.entities[fqn]; seen {
 		return existing
 	}
@@ -337,7 +511,12 @@ func (g *graph) mustSeen(fqn string) Entity {
 }
 
 func (g *graph) add(e Entity) {
-	g.entities[g.resolveFQN(e)] = e
+	fqn := g.resolveFQN(e)
+	g.entities[fqn] = e
+
+	if g.seen != nil {
+		g.seen.add(fqn, e)
+	}
 }
 
 func (g *graph) resolveFQN(e Entity) string {
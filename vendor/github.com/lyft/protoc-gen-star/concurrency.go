@@ -0,0 +1,94 @@
+package pgs
+
+import (
+	"sync"
+	"time"
+)
+
+// Options configures ProcessDescriptorsWithOptions.
+type Options struct {
+	// Parallelism is the number of files hydrated concurrently during the
+	// per-file pass of ProcessDescriptorsWithOptions. Values of 0 or 1
+	// hydrate sequentially, matching the historical behavior of
+	// ProcessDescriptors.
+	Parallelism int
+}
+
+// mustSeenTimeout bounds how long mustSeen will block waiting for another
+// shard to hydrate a cross-file reference during the concurrent pass, so a
+// reference that can never resolve (a malformed request, or a pathological
+// case where opts.Parallelism is too low relative to the dependency depth
+// of the file graph) surfaces a Debugger.Failf instead of hanging forever.
+const mustSeenTimeout = 30 * time.Second
+
+// seenResolver is a concurrency-safe Entity registry keyed by FQN, shared by
+// every shard graph during hydrateFilesConcurrently. Unlike graph.entities,
+// a lookup for an FQN that hasn't been added yet blocks (up to
+// mustSeenTimeout) instead of failing immediately, so cross-file references
+// resolve correctly no matter which file's shard happens to hydrate first.
+type seenResolver struct {
+	mu      sync.Mutex
+	ents    map[string]Entity
+	waiters map[string][]chan struct{}
+}
+
+func newSeenResolver() *seenResolver {
+	return &seenResolver{
+		ents:    make(map[string]Entity),
+		waiters: make(map[string][]chan struct{}),
+	}
+}
+
+// add registers e under fqn and wakes any goroutines blocked in get waiting
+// on it.
+func (r *seenResolver) add(fqn string, e Entity) {
+	r.mu.Lock()
+	r.ents[fqn] = e
+	waiting := r.waiters[fqn]
+	delete(r.waiters, fqn)
+	r.mu.Unlock()
+
+	for _, w := range waiting {
+		close(w)
+	}
+}
+
+// get blocks until fqn has been registered via add, or mustSeenTimeout
+// elapses, whichever comes first. The bool result is false on timeout. Each
+// call waits independently, so one goroutine timing out never affects
+// another still waiting on a different (or the same) FQN.
+func (r *seenResolver) get(fqn string) (Entity, bool) {
+	r.mu.Lock()
+	if e, ok := r.ents[fqn]; ok {
+		r.mu.Unlock()
+		return e, true
+	}
+
+	wait := make(chan struct{})
+	r.waiters[fqn] = append(r.waiters[fqn], wait)
+	r.mu.Unlock()
+
+	select {
+	case <-wait:
+		r.mu.Lock()
+		e, ok := r.ents[fqn]
+		r.mu.Unlock()
+		return e, ok
+	case <-time.After(mustSeenTimeout):
+		return nil, false
+	}
+}
+
+// entities returns a snapshot of everything registered so far. Called only
+// after every shard's goroutine has returned, so no further writers remain.
+func (r *seenResolver) entities() map[string]Entity {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make(map[string]Entity, len(r.ents))
+	for fqn, e := range r.ents {
+		out[fqn] = e
+	}
+
+	return out
+}
@@ -0,0 +1,71 @@
+package pgs
+
+// Debugger lets the AST hydration process report fatal errors without
+// panicking directly, so callers can control how failures surface (e.g. via
+// protoc's CodeGeneratorResponse.error).
+type Debugger interface {
+	// Fail logs msg as a fatal error.
+	Fail(msg string)
+
+	// Failf formats and logs a fatal error.
+	Failf(format string, a ...interface{})
+}
+
+// Name is a dotted identifier, either a file path (for File) or a
+// fully-qualified proto name.
+type Name string
+
+func (n Name) String() string { return string(n) }
+
+// Entity is the base interface implemented by every node in the AST graph.
+type Entity interface {
+	// FullyQualifiedName returns the FQN for this entity, using dot
+	// notation of the form ".{package}.{entity}". A File returns the FQN of
+	// its Package; the graph itself indexes Files by their input path.
+	FullyQualifiedName() string
+
+	// SetMeta attaches an arbitrary value to this Entity under key,
+	// overwriting any existing value for that key. Intended for use by
+	// ASTPlugins from the AfterHydrateX hooks.
+	SetMeta(key, val interface{})
+
+	// Meta returns the value previously attached under key via SetMeta, if
+	// any.
+	Meta(key interface{}) (interface{}, bool)
+
+	addSourceCodeInfo(info SourceCodeInfo)
+}
+
+// ParentEntity is implemented by Entities that may lexically contain other
+// Entities: File and Message.
+type ParentEntity interface {
+	Entity
+
+	// childAtPath resolves the Entity (if any) declared at the given
+	// SourceCodeInfo path, relative to this ParentEntity.
+	childAtPath(path []int32) Entity
+}
+
+// childAtPathIndex resolves path[0] against get (bounds-checked by the
+// caller's closure returning nil for an out-of-range index), then recurses
+// into the result if it is itself a ParentEntity and more path remains.
+func childAtPathIndex(path []int32, get func(i int) Entity) Entity {
+	if len(path) == 0 {
+		return nil
+	}
+
+	e := get(int(path[0]))
+	if e == nil {
+		return nil
+	}
+
+	if len(path) == 1 {
+		return e
+	}
+
+	if pe, ok := e.(ParentEntity); ok {
+		return pe.childAtPath(path[1:])
+	}
+
+	return e
+}
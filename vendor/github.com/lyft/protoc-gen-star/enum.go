@@ -0,0 +1,62 @@
+package pgs
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// Enum describes a single proto enum declaration, whether declared at file
+// scope or nested inside a Message.
+type Enum interface {
+	Entity
+
+	// Parent returns the File or Message this Enum is declared within.
+	Parent() ParentEntity
+
+	// Values returns this Enum's declared values, in declaration order.
+	Values() []EnumValue
+
+	addValue(v EnumValue)
+}
+
+type enum struct {
+	entityMeta
+	sourceCodeInfo
+
+	desc   *descriptor.EnumDescriptorProto
+	parent ParentEntity
+	vals   []EnumValue
+}
+
+func (e *enum) FullyQualifiedName() string {
+	return e.parent.FullyQualifiedName() + "." + e.desc.GetName()
+}
+
+func (e *enum) Parent() ParentEntity { return e.parent }
+
+func (e *enum) Values() []EnumValue { return e.vals }
+
+func (e *enum) addValue(v EnumValue) { e.vals = append(e.vals, v) }
+
+var _ Enum = (*enum)(nil)
+
+// EnumValue describes a single named value of an Enum.
+type EnumValue interface {
+	Entity
+
+	// Enum returns the Enum this EnumValue belongs to.
+	Enum() Enum
+}
+
+type enumVal struct {
+	entityMeta
+	sourceCodeInfo
+
+	desc *descriptor.EnumValueDescriptorProto
+	enum Enum
+}
+
+func (v *enumVal) FullyQualifiedName() string {
+	return v.enum.FullyQualifiedName() + "." + v.desc.GetName()
+}
+
+func (v *enumVal) Enum() Enum { return v.enum }
+
+var _ EnumValue = (*enumVal)(nil)
@@ -0,0 +1,95 @@
+package pgs
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// Extension describes a member of a proto2/proto3 "extend" block. Unlike a
+// regular Field, an Extension is declared lexically outside of the Message it
+// applies to, so it carries its own Extendee reference in addition to the
+// usual FieldType info. Extensions may be declared at file scope (extending
+// any visible Message) or nested inside a Message (conventionally used to
+// scope custom options to that Message's own namespace); either way, Parent
+// returns the File or Message the Extension was declared within.
+//
+// Extension satisfies Field so that it can be run through the same
+// FieldType hydration pipeline as an ordinary field; its Message returns
+// Extendee rather than a declaring parent, since that is the Message whose
+// namespace the extension's value is resolved against.
+type Extension interface {
+	Entity
+	Field
+
+	// Extendee returns the Message being extended by this Extension.
+	Extendee() Message
+
+	// Parent returns the lexical scope (File or Message) that declared this
+	// Extension. This is distinct from Extendee, which may be an entirely
+	// different Message.
+	Parent() ParentEntity
+
+	setExtendee(m Message)
+}
+
+const (
+	// extensionPath is the FileDescriptorProto field number for top-level
+	// extend-block members; File.childAtPath routes SourceCodeInfo
+	// locations under this path segment to the matching Extension.
+	extensionPath = 7
+
+	// msgExtensionPath is the DescriptorProto field number for extend-block
+	// members nested within a Message; Message.childAtPath routes
+	// SourceCodeInfo locations under this path segment to the matching
+	// Extension.
+	msgExtensionPath = 6
+)
+
+type ext struct {
+	typ      FieldType
+	desc     *descriptor.FieldDescriptorProto
+	parent   ParentEntity
+	extendee Message
+
+	sourceCodeInfo
+	entityMeta
+}
+
+func (e *ext) Descriptor() *descriptor.FieldDescriptorProto { return e.desc }
+
+// FullyQualifiedName uses Parent (the lexical scope this Extension was
+// declared in), not Extendee: like a Message or Enum, an Extension's FQN is
+// fixed by where it's declared, not by what it extends, and Extendee isn't
+// resolved until after this Extension is already added to the graph.
+func (e *ext) FullyQualifiedName() string {
+	return e.parent.FullyQualifiedName() + "." + e.desc.GetName()
+}
+
+func (e *ext) Extendee() Message { return e.extendee }
+
+func (e *ext) Parent() ParentEntity { return e.parent }
+
+func (e *ext) Type() FieldType { return e.typ }
+
+func (e *ext) addType(ft FieldType) { e.typ = ft }
+
+func (e *ext) InOneOf() bool { return false }
+
+func (e *ext) InRealOneOf() bool { return false }
+
+func (e *ext) OneOf() OneOf { return nil }
+
+func (e *ext) setOneOf(OneOf) {}
+
+// HasPresence is true for every singular Extension: the extend mechanism has
+// no singular-scalar-field-without-presence case the way proto3 fields do.
+// Repeated Extensions, like repeated fields, have no presence.
+func (e *ext) HasPresence() bool {
+	return e.desc.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED
+}
+
+// Message returns the Extendee. Extensions have no declaring message of
+// their own; type resolution only cares which Message's namespace they
+// extend.
+func (e *ext) Message() Message { return e.extendee }
+
+func (e *ext) setExtendee(m Message) { e.extendee = m }
+
+var _ Extension = (*ext)(nil)
@@ -0,0 +1,93 @@
+package pgs
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// Field describes a single field declared on a Message.
+type Field interface {
+	Entity
+
+	// Descriptor returns the raw FieldDescriptorProto this Field was
+	// hydrated from.
+	Descriptor() *descriptor.FieldDescriptorProto
+
+	// Message returns the Message this Field is declared on.
+	Message() Message
+
+	// Type returns this Field's resolved FieldType.
+	Type() FieldType
+
+	// InOneOf reports whether this Field is a member of any oneof,
+	// synthetic or otherwise. Most callers checking for a user-authored
+	// oneof should use InRealOneOf instead.
+	InOneOf() bool
+
+	// InRealOneOf reports whether this Field is a member of a oneof a user
+	// actually wrote, excluding the compiler-synthesized oneof wrapping a
+	// proto3 optional field.
+	InRealOneOf() bool
+
+	// OneOf returns the OneOf this Field belongs to, or nil if it is not a
+	// member of one.
+	OneOf() OneOf
+
+	// HasPresence reports whether this Field distinguishes "not set" from
+	// its zero value on the wire: proto3 optional fields, members of a real
+	// oneof, singular embedded message fields, and (since proto2 gives every
+	// singular field a has-bit) any singular field in a proto2 file all do;
+	// plain singular scalar fields in a proto3 file and repeated fields of
+	// any kind do not.
+	HasPresence() bool
+
+	addType(t FieldType)
+	setOneOf(o OneOf)
+}
+
+type field struct {
+	entityMeta
+	sourceCodeInfo
+
+	desc  *descriptor.FieldDescriptorProto
+	msg   Message
+	typ   FieldType
+	oneof OneOf
+}
+
+func (f *field) Descriptor() *descriptor.FieldDescriptorProto { return f.desc }
+
+func (f *field) FullyQualifiedName() string {
+	return f.msg.FullyQualifiedName() + "." + f.desc.GetName()
+}
+
+func (f *field) Message() Message { return f.msg }
+
+func (f *field) Type() FieldType { return f.typ }
+
+func (f *field) addType(t FieldType) { f.typ = t }
+
+func (f *field) InOneOf() bool { return f.oneof != nil }
+
+func (f *field) InRealOneOf() bool { return f.oneof != nil && !f.oneof.IsSynthetic() }
+
+func (f *field) OneOf() OneOf { return f.oneof }
+
+func (f *field) setOneOf(o OneOf) { f.oneof = o }
+
+func (f *field) HasPresence() bool {
+	if f.InRealOneOf() || f.desc.GetProto3Optional() {
+		return true
+	}
+
+	if f.desc.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		return false
+	}
+
+	if f.typ != nil && f.typ.IsEmbed() {
+		return true
+	}
+
+	// proto2 gives every singular field an explicit has-bit, regardless of
+	// type; proto3 only does for the cases already handled above.
+	return f.msg.File().Syntax() != "proto3"
+}
+
+var _ Field = (*field)(nil)
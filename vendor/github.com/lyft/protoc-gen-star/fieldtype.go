@@ -0,0 +1,176 @@
+package pgs
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// ProtoType mirrors FieldDescriptorProto_Type, identifying a field's wire
+// representation.
+type ProtoType descriptor.FieldDescriptorProto_Type
+
+// Label mirrors FieldDescriptorProto_Label, identifying a field's
+// cardinality.
+type Label descriptor.FieldDescriptorProto_Label
+
+const (
+	// GroupT identifies the deprecated proto2 group wire type.
+	GroupT = ProtoType(descriptor.FieldDescriptorProto_TYPE_GROUP)
+
+	// EnumT identifies an enum-typed field.
+	EnumT = ProtoType(descriptor.FieldDescriptorProto_TYPE_ENUM)
+
+	// MessageT identifies an embedded-message-typed field.
+	MessageT = ProtoType(descriptor.FieldDescriptorProto_TYPE_MESSAGE)
+)
+
+// Repeated identifies a repeated field's Label.
+const Repeated = Label(descriptor.FieldDescriptorProto_LABEL_REPEATED)
+
+// FieldType describes the proto type of a Field or Extension: a scalar,
+// enum, or embedded message, and the repeated/map variant of each.
+type FieldType interface {
+	// ProtoType returns the underlying wire type of this field.
+	ProtoType() ProtoType
+
+	// ProtoLabel returns the cardinality of this field.
+	ProtoLabel() Label
+
+	// IsRepeated reports whether this FieldType is a repeated (including
+	// map) field.
+	IsRepeated() bool
+
+	// IsMap reports whether this FieldType is a map field.
+	IsMap() bool
+
+	// IsEnum reports whether this FieldType's scalar/element type is an
+	// enum.
+	IsEnum() bool
+
+	// Enum returns the Enum for an enum-typed FieldType (or its repeated
+	// element), nil otherwise.
+	Enum() Enum
+
+	// IsEmbed reports whether this FieldType's scalar/element type is an
+	// embedded message.
+	IsEmbed() bool
+
+	// Embed returns the Message for an embedded-message-typed FieldType (or
+	// its repeated element), nil otherwise.
+	Embed() Message
+
+	toElem() FieldElem
+}
+
+// FieldElem is the type of a single repeated/map element: either the
+// element of a repeated FieldType, or the key/value of a map FieldType.
+type FieldElem interface {
+	FieldType
+
+	setType(t FieldType)
+}
+
+type scalarT struct {
+	entityMeta
+	fld Field
+}
+
+func (s *scalarT) ProtoType() ProtoType { return ProtoType(s.fld.Descriptor().GetType()) }
+func (s *scalarT) ProtoLabel() Label    { return Label(s.fld.Descriptor().GetLabel()) }
+func (s *scalarT) IsRepeated() bool     { return false }
+func (s *scalarT) IsMap() bool          { return false }
+func (s *scalarT) IsEnum() bool         { return false }
+func (s *scalarT) Enum() Enum           { return nil }
+func (s *scalarT) IsEmbed() bool        { return false }
+func (s *scalarT) Embed() Message       { return nil }
+
+func (s *scalarT) toElem() FieldElem {
+	return &scalarE{typ: s, ptype: s.ProtoType()}
+}
+
+type enumT struct {
+	*scalarT
+	enum Enum
+}
+
+func (e *enumT) IsEnum() bool { return true }
+func (e *enumT) Enum() Enum   { return e.enum }
+
+func (e *enumT) toElem() FieldElem {
+	return &enumE{scalarE: &scalarE{typ: e, ptype: e.ProtoType()}, enum: e.enum}
+}
+
+type embedT struct {
+	*scalarT
+	msg Message
+}
+
+func (e *embedT) IsEmbed() bool  { return true }
+func (e *embedT) Embed() Message { return e.msg }
+
+func (e *embedT) toElem() FieldElem {
+	return &embedE{scalarE: &scalarE{typ: e, ptype: e.ProtoType()}, msg: e.msg}
+}
+
+type repT struct {
+	*scalarT
+	el FieldElem
+}
+
+func (r *repT) IsRepeated() bool  { return true }
+func (r *repT) IsEnum() bool      { return r.el.IsEnum() }
+func (r *repT) Enum() Enum        { return r.el.Enum() }
+func (r *repT) IsEmbed() bool     { return r.el.IsEmbed() }
+func (r *repT) Embed() Message    { return r.el.Embed() }
+func (r *repT) toElem() FieldElem { return r.el }
+
+type mapT struct {
+	*repT
+	key FieldElem
+	el  FieldElem
+}
+
+func (m *mapT) IsMap() bool { return true }
+
+// scalarE is the element type of a repeated scalar field, or the key/value
+// of a map whose corresponding side is scalar.
+type scalarE struct {
+	entityMeta
+	typ   FieldType
+	ptype ProtoType
+}
+
+func (s *scalarE) ProtoType() ProtoType { return s.ptype }
+func (s *scalarE) ProtoLabel() Label    { return Repeated }
+func (s *scalarE) IsRepeated() bool     { return true }
+func (s *scalarE) IsMap() bool          { return false }
+func (s *scalarE) IsEnum() bool         { return false }
+func (s *scalarE) Enum() Enum           { return nil }
+func (s *scalarE) IsEmbed() bool        { return false }
+func (s *scalarE) Embed() Message       { return nil }
+func (s *scalarE) toElem() FieldElem    { return s }
+func (s *scalarE) setType(t FieldType)  { s.typ = t }
+
+type enumE struct {
+	*scalarE
+	enum Enum
+}
+
+func (e *enumE) IsEnum() bool { return true }
+func (e *enumE) Enum() Enum   { return e.enum }
+
+type embedE struct {
+	*scalarE
+	msg Message
+}
+
+func (e *embedE) IsEmbed() bool  { return true }
+func (e *embedE) Embed() Message { return e.msg }
+
+var (
+	_ FieldType = (*scalarT)(nil)
+	_ FieldType = (*enumT)(nil)
+	_ FieldType = (*embedT)(nil)
+	_ FieldType = (*repT)(nil)
+	_ FieldType = (*mapT)(nil)
+	_ FieldElem = (*scalarE)(nil)
+	_ FieldElem = (*enumE)(nil)
+	_ FieldElem = (*embedE)(nil)
+)
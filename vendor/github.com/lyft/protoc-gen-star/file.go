@@ -0,0 +1,151 @@
+package pgs
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// File describes a single proto source file and everything declared in it.
+type File interface {
+	ParentEntity
+
+	// Name returns the path of this File, exactly as provided to protoc.
+	Name() Name
+
+	// Package returns the proto package this File declares itself part of.
+	Package() Package
+
+	// Syntax returns this File's declared proto syntax, "proto2" or
+	// "proto3". An unset syntax field defaults to "proto2", matching
+	// protoc's own behavior.
+	Syntax() string
+
+	// BuildTarget reports whether this File was passed on the command line
+	// to protoc (as opposed to pulled in transitively as a dependency).
+	BuildTarget() bool
+
+	// Enums returns the top-level Enums declared directly in this File.
+	Enums() []Enum
+
+	// Messages returns the top-level Messages declared directly in this
+	// File.
+	Messages() []Message
+
+	// AllMessages returns every Message declared in this File, including
+	// nested messages and map entries, depth-first: each Message is followed
+	// by its nested Messages and then by its map entries.
+	AllMessages() []Message
+
+	// Services returns the Services declared in this File.
+	Services() []Service
+
+	// Extensions returns the top-level Extensions ("extend" blocks)
+	// declared directly in this File.
+	Extensions() []Extension
+
+	// addPackageSourceCodeInfo attaches the SourceCodeInfo for this File's
+	// package statement, separate from the File's own comments.
+	addPackageSourceCodeInfo(info SourceCodeInfo)
+
+	addEnum(e Enum)
+	addMessage(m Message)
+	addService(s Service)
+	addExtension(e Extension)
+}
+
+type file struct {
+	entityMeta
+	sourceCodeInfo
+	pkgInfo SourceCodeInfo
+
+	pkg         Package
+	desc        *descriptor.FileDescriptorProto
+	buildTarget bool
+
+	enums []Enum
+	msgs  []Message
+	srvs  []Service
+	exts  []Extension
+}
+
+func (f *file) Name() Name                { return Name(f.desc.GetName()) }
+func (f *file) FullyQualifiedName() string { return f.pkg.FullyQualifiedName() }
+func (f *file) Package() Package           { return f.pkg }
+func (f *file) BuildTarget() bool          { return f.buildTarget }
+func (f *file) Enums() []Enum              { return f.enums }
+func (f *file) Messages() []Message        { return f.msgs }
+func (f *file) Services() []Service        { return f.srvs }
+func (f *file) Extensions() []Extension    { return f.exts }
+
+func (f *file) Syntax() string {
+	if s := f.desc.GetSyntax(); s != "" {
+		return s
+	}
+	return "proto2"
+}
+
+func (f *file) AllMessages() []Message {
+	all := make([]Message, 0, len(f.msgs))
+
+	var walk func(msgs []Message)
+	walk = func(msgs []Message) {
+		for _, m := range msgs {
+			all = append(all, m)
+			walk(m.Messages())
+			walk(m.MapEntries())
+		}
+	}
+	walk(f.msgs)
+
+	return all
+}
+
+func (f *file) addEnum(e Enum)           { f.enums = append(f.enums, e) }
+func (f *file) addMessage(m Message)     { f.msgs = append(f.msgs, m) }
+func (f *file) addService(s Service)     { f.srvs = append(f.srvs, s) }
+func (f *file) addExtension(e Extension) { f.exts = append(f.exts, e) }
+
+func (f *file) addPackageSourceCodeInfo(info SourceCodeInfo) { f.pkgInfo = info }
+
+func (f *file) PackageSourceCodeInfo() SourceCodeInfo { return f.pkgInfo }
+
+// childAtPath routes a top-level SourceCodeInfo path to the Entity declared
+// there: a message_type, enum_type, service, or top-level extend-block
+// member, recursing further if the path continues into that Entity.
+func (f *file) childAtPath(path []int32) Entity {
+	if len(path) < 2 {
+		return nil
+	}
+
+	switch path[0] {
+	case messageTypePath:
+		return childAtPathIndex(path[1:], func(i int) Entity {
+			if i < 0 || i >= len(f.msgs) {
+				return nil
+			}
+			return f.msgs[i]
+		})
+	case enumTypePath:
+		return childAtPathIndex(path[1:], func(i int) Entity {
+			if i < 0 || i >= len(f.enums) {
+				return nil
+			}
+			return f.enums[i]
+		})
+	case servicePath:
+		return childAtPathIndex(path[1:], func(i int) Entity {
+			if i < 0 || i >= len(f.srvs) {
+				return nil
+			}
+			return f.srvs[i]
+		})
+	case extensionPath:
+		return childAtPathIndex(path[1:], func(i int) Entity {
+			if i < 0 || i >= len(f.exts) {
+				return nil
+			}
+			return f.exts[i]
+		})
+	default:
+		return nil
+	}
+}
+
+var _ File = (*file)(nil)
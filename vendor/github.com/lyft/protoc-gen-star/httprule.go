@@ -0,0 +1,231 @@
+package pgs
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"google.golang.org/genproto/googleapis/api/annotations"
+)
+
+// HTTPRule is a structured view of a single google.api.http binding declared
+// on a Method's MethodOptions, following the same option that grpc-gateway's
+// descriptor package consumes. The raw path template is tokenized into
+// PathSegments, with each variable segment resolved against the Method's
+// input Message so generators get typed field references instead of having
+// to re-parse the template themselves.
+type HTTPRule struct {
+	// Verb is the HTTP verb this rule binds to (GET, POST, PATCH, ...), or
+	// the custom verb name for a "custom" pattern.
+	Verb string
+
+	// Path is the tokenized path template, in declaration order.
+	Path []PathSegment
+
+	// Body selects the request field ("*" for the whole message, "" for
+	// none) mapped to the HTTP request body.
+	Body string
+
+	// ResponseBody selects the response field ("" for the whole message)
+	// mapped to the HTTP response body.
+	ResponseBody string
+}
+
+// PathSegmentKind identifies which of the four google.api.http path template
+// constructs a PathSegment represents.
+type PathSegmentKind int
+
+const (
+	// LiteralSegment is a fixed path component, matched verbatim.
+	LiteralSegment PathSegmentKind = iota
+
+	// WildcardSegment is a single "*" path component, matching exactly one
+	// path segment without binding it to a field.
+	WildcardSegment
+
+	// CatchAllSegment is a "**" path component, matching any number of
+	// trailing path segments without binding them to a field.
+	CatchAllSegment
+
+	// VariableSegment is a "{name=pattern}" path component, bound to Field.
+	VariableSegment
+)
+
+// PathSegment is a single tokenized component of an HTTPRule's Path.
+type PathSegment struct {
+	Kind PathSegmentKind
+
+	// Literal is the matched text for LiteralSegment, or the sub-pattern
+	// text for VariableSegment (e.g. "*" or "a/b/*").
+	Literal string
+
+	// Name is the variable name for VariableSegment (e.g. "name" in
+	// "{name=shelves/*}"). Empty for all other Kinds.
+	Name string
+
+	// Field is the scalar Field within the Method's input Message that Name
+	// resolves to, following dotted sub-field paths. Nil for all Kinds but
+	// VariableSegment.
+	Field Field
+}
+
+// hydrateHTTPRules parses the google.api.http option off of md, if present,
+// into the HTTPRules exposed by the hydrated Method.
+func (g *graph) hydrateHTTPRules(m Method, md *descriptor.MethodDescriptorProto) []HTTPRule {
+	opts := md.GetOptions()
+	if opts == nil || !proto.HasExtension(opts, annotations.E_Http) {
+		return nil
+	}
+
+	raw, err := proto.GetExtension(opts, annotations.E_Http)
+	if err != nil {
+		g.d.Failf("invalid google.api.http option on method %s: %v", m.FullyQualifiedName(), err)
+		return nil
+	}
+
+	return g.hydrateHTTPRule(m, raw.(*annotations.HttpRule), 0)
+}
+
+// hydrateHTTPRule converts a single HttpRule into one or more HTTPRules,
+// recursing into additional_bindings. depth is bounded to 1, matching the
+// reference implementation in grpc-gateway: additional_bindings declared
+// inside an additional binding are ignored.
+func (g *graph) hydrateHTTPRule(m Method, r *annotations.HttpRule, depth int) []HTTPRule {
+	if r == nil {
+		return nil
+	}
+
+	verb, tmpl := httpRuleVerbAndTemplate(r)
+	rules := []HTTPRule{{
+		Verb:         verb,
+		Path:         g.tokenizeHTTPPath(m, tmpl),
+		Body:         r.GetBody(),
+		ResponseBody: r.GetResponseBody(),
+	}}
+
+	if depth == 0 {
+		for _, ab := range r.GetAdditionalBindings() {
+			rules = append(rules, g.hydrateHTTPRule(m, ab, depth+1)...)
+		}
+	}
+
+	return rules
+}
+
+func httpRuleVerbAndTemplate(r *annotations.HttpRule) (verb, tmpl string) {
+	switch pattern := r.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return "GET", pattern.Get
+	case *annotations.HttpRule_Put:
+		return "PUT", pattern.Put
+	case *annotations.HttpRule_Post:
+		return "POST", pattern.Post
+	case *annotations.HttpRule_Delete:
+		return "DELETE", pattern.Delete
+	case *annotations.HttpRule_Patch:
+		return "PATCH", pattern.Patch
+	case *annotations.HttpRule_Custom:
+		return pattern.Custom.GetKind(), pattern.Custom.GetPath()
+	default:
+		return "", ""
+	}
+}
+
+// tokenizeHTTPPath splits a path template into literal, wildcard, catch-all,
+// and variable PathSegments, resolving each variable against m's input
+// Message. A "{name=pattern}" variable is scanned as a single atomic token
+// before any splitting on "/", since its pattern may itself contain "/",
+// "*", and "**" sub-segments (e.g. "{name=shelves/*/books/*}") that must
+// stay part of the one VariableSegment rather than being split out as their
+// own segments.
+func (g *graph) tokenizeHTTPPath(m Method, tmpl string) []PathSegment {
+	tmpl = strings.Trim(tmpl, "/")
+
+	var segs []PathSegment
+	for len(tmpl) > 0 {
+		var part string
+
+		if tmpl[0] == '{' {
+			end := strings.IndexByte(tmpl, '}')
+			if end < 0 {
+				g.d.Failf("method %s has an unterminated path variable in template %q", m.FullyQualifiedName(), tmpl)
+				return segs
+			}
+
+			part = tmpl[:end+1]
+			tmpl = strings.TrimPrefix(tmpl[end+1:], "/")
+		} else if idx := strings.IndexByte(tmpl, '/'); idx >= 0 {
+			part = tmpl[:idx]
+			tmpl = tmpl[idx+1:]
+		} else {
+			part = tmpl
+			tmpl = ""
+		}
+
+		switch {
+		case part == "*":
+			segs = append(segs, PathSegment{Kind: WildcardSegment, Literal: part})
+		case part == "**":
+			segs = append(segs, PathSegment{Kind: CatchAllSegment, Literal: part})
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+			segs = append(segs, g.hydrateHTTPVariable(m, part[1:len(part)-1]))
+		default:
+			segs = append(segs, PathSegment{Kind: LiteralSegment, Literal: part})
+		}
+	}
+
+	return segs
+}
+
+func (g *graph) hydrateHTTPVariable(m Method, raw string) PathSegment {
+	name, pattern := raw, "*"
+	if idx := strings.IndexByte(raw, '='); idx >= 0 {
+		name, pattern = raw[:idx], raw[idx+1:]
+	}
+
+	fld, ok := resolveHTTPField(m.Input(), name)
+	if !ok {
+		g.d.Failf(
+			"path variable %q on method %s does not resolve to a scalar field of %s",
+			name, m.FullyQualifiedName(), m.Input().FullyQualifiedName(),
+		)
+	}
+
+	return PathSegment{Kind: VariableSegment, Name: name, Literal: pattern, Field: fld}
+}
+
+// resolveHTTPField walks a dotted field path (e.g. "shelf.name") against m's
+// Fields, requiring every non-terminal segment to resolve to a singular
+// embedded message field (so the walk can descend into it) and the terminal
+// field to be scalar.
+func resolveHTTPField(m Message, path string) (Field, bool) {
+	cur := m
+	names := strings.Split(path, ".")
+
+	for i, name := range names {
+		var found Field
+		for _, f := range cur.Fields() {
+			if f.Descriptor().GetName() == name {
+				found = f
+				break
+			}
+		}
+		if found == nil {
+			return nil, false
+		}
+
+		if i == len(names)-1 {
+			if found.Type().IsRepeated() || found.Type().IsEmbed() {
+				return nil, false
+			}
+			return found, true
+		}
+
+		if found.Type().IsRepeated() || !found.Type().IsEmbed() {
+			return nil, false
+		}
+		cur = found.Type().Embed()
+	}
+
+	return nil, false
+}
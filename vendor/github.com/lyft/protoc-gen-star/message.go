@@ -0,0 +1,225 @@
+package pgs
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// Message describes a single proto message, whether declared at file scope
+// or nested inside another Message.
+type Message interface {
+	ParentEntity
+
+	// File returns the File this Message is declared in, walking up through
+	// any enclosing Messages for a nested type.
+	File() File
+
+	// IsMapEntry reports whether this Message is the compiler-synthesized
+	// entry type backing a map field, rather than a message a user
+	// declared.
+	IsMapEntry() bool
+
+	// Messages returns the nested Messages declared directly in this
+	// Message, excluding map entries.
+	Messages() []Message
+
+	// MapEntries returns the map-entry Messages synthesized for this
+	// Message's map fields.
+	MapEntries() []Message
+
+	// Enums returns the Enums declared directly in this Message.
+	Enums() []Enum
+
+	// Fields returns this Message's fields, in declaration order.
+	Fields() []Field
+
+	// OneOfs returns the oneofs a user actually declared on this Message,
+	// excluding the compiler-synthesized ones backing proto3 optional
+	// fields. Most callers want this rather than AllOneOfs.
+	OneOfs() []OneOf
+
+	// SyntheticOneOfs returns the compiler-synthesized oneofs backing this
+	// Message's proto3 optional fields.
+	SyntheticOneOfs() []OneOf
+
+	// AllOneOfs returns every oneof on this Message, real and synthetic, in
+	// declaration order.
+	AllOneOfs() []OneOf
+
+	// Extensions returns the Extensions ("extend" block members) declared
+	// lexically inside this Message.
+	Extensions() []Extension
+
+	// Extendees returns the Extensions declared anywhere that extend this
+	// Message.
+	Extendees() []Extension
+
+	addMessage(m Message)
+	addMapEntry(m Message)
+	addEnum(e Enum)
+	addOneOf(o OneOf)
+	addField(f Field)
+	addExtension(e Extension)
+	addExtendee(e Extension)
+}
+
+// DescriptorProto field numbers used by Message.childAtPath to route nested
+// SourceCodeInfo locations.
+const (
+	msgFieldPath      = 2
+	msgNestedTypePath = 3
+	msgEnumTypePath   = 4
+	msgOneofDeclPath  = 8
+)
+
+type msg struct {
+	entityMeta
+	sourceCodeInfo
+
+	desc   *descriptor.DescriptorProto
+	parent ParentEntity
+
+	// preservedMsgs holds every nested message in declaration order
+	// (regardless of map-entry-ness), indexed by position in
+	// DescriptorProto.nested_type the way FieldDescriptorProto.OneofIndex
+	// indexes oneof_decl: so
+	// callers needing positional lookup by the raw descriptor don't have to
+	// re-derive it from the msgs/mapEntries split below.
+	preservedMsgs []Message
+
+	msgs       []Message
+	mapEntries []Message
+	enums      []Enum
+	oneofs     []OneOf
+	flds       []Field
+	exts       []Extension
+
+	// extendeesMu guards extendees, which - unlike the other slices above -
+	// can be appended to by a shard other than the one that hydrated this
+	// Message: an extend block in any file hydrated concurrently may name
+	// this Message as its extendee.
+	extendeesMu sync.Mutex
+	extendees   []Extension
+}
+
+// File walks up m.parent until it reaches the enclosing File, recursing
+// through any chain of nested Messages.
+func (m *msg) File() File {
+	if f, ok := m.parent.(File); ok {
+		return f
+	}
+	return m.parent.(Message).File()
+}
+
+func (m *msg) IsMapEntry() bool { return m.desc.GetOptions().GetMapEntry() }
+
+func (m *msg) FullyQualifiedName() string {
+	return m.parent.FullyQualifiedName() + "." + m.desc.GetName()
+}
+
+func (m *msg) Messages() []Message     { return m.msgs }
+func (m *msg) MapEntries() []Message   { return m.mapEntries }
+func (m *msg) Enums() []Enum           { return m.enums }
+func (m *msg) Fields() []Field         { return m.flds }
+func (m *msg) Extensions() []Extension { return m.exts }
+func (m *msg) AllOneOfs() []OneOf      { return m.oneofs }
+
+// Extendees is safe to call while other shards are still concurrently
+// appending to it via addExtendee, returning a snapshot copy rather than the
+// live slice.
+func (m *msg) Extendees() []Extension {
+	m.extendeesMu.Lock()
+	defer m.extendeesMu.Unlock()
+
+	out := make([]Extension, len(m.extendees))
+	copy(out, m.extendees)
+	return out
+}
+
+func (m *msg) OneOfs() []OneOf {
+	out := make([]OneOf, 0, len(m.oneofs))
+	for _, o := range m.oneofs {
+		if !o.IsSynthetic() {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+func (m *msg) SyntheticOneOfs() []OneOf {
+	out := make([]OneOf, 0, len(m.oneofs))
+	for _, o := range m.oneofs {
+		if o.IsSynthetic() {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+func (m *msg) addMessage(nm Message)    { m.msgs = append(m.msgs, nm) }
+func (m *msg) addMapEntry(nm Message)   { m.mapEntries = append(m.mapEntries, nm) }
+func (m *msg) addEnum(e Enum)           { m.enums = append(m.enums, e) }
+func (m *msg) addOneOf(o OneOf)         { m.oneofs = append(m.oneofs, o) }
+func (m *msg) addField(f Field)         { m.flds = append(m.flds, f) }
+func (m *msg) addExtension(e Extension) { m.exts = append(m.exts, e) }
+
+// addExtendee is called from hydrateExtensionType, which may run in a
+// different shard's goroutine than the one that hydrated this Message, so
+// the append must be synchronized.
+func (m *msg) addExtendee(e Extension) {
+	m.extendeesMu.Lock()
+	defer m.extendeesMu.Unlock()
+
+	m.extendees = append(m.extendees, e)
+}
+
+// childAtPath routes a nested SourceCodeInfo path to the field, nested
+// message, enum, oneof, or extend-block member declared there.
+func (m *msg) childAtPath(path []int32) Entity {
+	if len(path) < 2 {
+		return nil
+	}
+
+	switch path[0] {
+	case msgFieldPath:
+		return childAtPathIndex(path[1:], func(i int) Entity {
+			if i < 0 || i >= len(m.flds) {
+				return nil
+			}
+			return m.flds[i]
+		})
+	case msgNestedTypePath:
+		return childAtPathIndex(path[1:], func(i int) Entity {
+			if i < 0 || i >= len(m.preservedMsgs) {
+				return nil
+			}
+			return m.preservedMsgs[i]
+		})
+	case msgEnumTypePath:
+		return childAtPathIndex(path[1:], func(i int) Entity {
+			if i < 0 || i >= len(m.enums) {
+				return nil
+			}
+			return m.enums[i]
+		})
+	case msgOneofDeclPath:
+		return childAtPathIndex(path[1:], func(i int) Entity {
+			if i < 0 || i >= len(m.oneofs) {
+				return nil
+			}
+			return m.oneofs[i]
+		})
+	case msgExtensionPath:
+		return childAtPathIndex(path[1:], func(i int) Entity {
+			if i < 0 || i >= len(m.exts) {
+				return nil
+			}
+			return m.exts[i]
+		})
+	default:
+		return nil
+	}
+}
+
+var _ Message = (*msg)(nil)
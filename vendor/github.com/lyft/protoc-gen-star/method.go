@@ -0,0 +1,55 @@
+package pgs
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// Method describes a single RPC method declared on a Service.
+type Method interface {
+	Entity
+
+	// Descriptor returns the raw MethodDescriptorProto this Method was
+	// hydrated from.
+	Descriptor() *descriptor.MethodDescriptorProto
+
+	// Service returns the Service this Method is declared on.
+	Service() Service
+
+	// Input returns the Message this Method accepts as its request.
+	Input() Message
+
+	// Output returns the Message this Method returns as its response.
+	Output() Message
+
+	// HTTPRules returns the google.api.http bindings declared on this
+	// Method's MethodOptions, if any.
+	HTTPRules() []HTTPRule
+
+	addHTTPRules(r []HTTPRule)
+}
+
+type method struct {
+	entityMeta
+	sourceCodeInfo
+
+	desc      *descriptor.MethodDescriptorProto
+	service   Service
+	in, out   Message
+	httpRules []HTTPRule
+}
+
+func (m *method) Descriptor() *descriptor.MethodDescriptorProto { return m.desc }
+
+func (m *method) FullyQualifiedName() string {
+	return m.service.FullyQualifiedName() + "." + m.desc.GetName()
+}
+
+func (m *method) Service() Service { return m.service }
+
+func (m *method) Input() Message { return m.in }
+
+func (m *method) Output() Message { return m.out }
+
+func (m *method) HTTPRules() []HTTPRule { return m.httpRules }
+
+func (m *method) addHTTPRules(r []HTTPRule) { m.httpRules = r }
+
+var _ Method = (*method)(nil)
@@ -0,0 +1,53 @@
+package pgs
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// OneOf describes a proto oneof declaration, grouping the Fields that are
+// mutually exclusive on the wire.
+type OneOf interface {
+	Entity
+
+	// Message returns the Message this OneOf is declared on.
+	Message() Message
+
+	// Fields returns the Fields belonging to this OneOf, in declaration
+	// order.
+	Fields() []Field
+
+	// IsSynthetic reports whether this OneOf was synthesized by protoc to
+	// track presence for a proto3 optional field, rather than declared by
+	// a user. A synthetic OneOf always has exactly one Field.
+	IsSynthetic() bool
+
+	addField(f Field)
+	setSynthetic(synthetic bool)
+}
+
+type oneof struct {
+	entityMeta
+	sourceCodeInfo
+
+	desc      *descriptor.OneofDescriptorProto
+	msg       Message
+	flds      []Field
+	synthetic bool
+}
+
+func (o *oneof) FullyQualifiedName() string {
+	return o.msg.FullyQualifiedName() + "." + o.desc.GetName()
+}
+
+func (o *oneof) Message() Message { return o.msg }
+
+func (o *oneof) Fields() []Field { return o.flds }
+
+func (o *oneof) IsSynthetic() bool { return o.synthetic }
+
+func (o *oneof) addField(f Field) {
+	o.flds = append(o.flds, f)
+	f.setOneOf(o)
+}
+
+func (o *oneof) setSynthetic(synthetic bool) { o.synthetic = synthetic }
+
+var _ OneOf = (*oneof)(nil)
@@ -0,0 +1,41 @@
+package pgs
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// Package groups together every File sharing the same proto package
+// statement, including Files pulled in transitively as dependencies.
+type Package interface {
+	Entity
+
+	// ProtoName returns the declared proto package name (e.g. "foo.bar"),
+	// which may be empty for files with no package statement.
+	ProtoName() Name
+
+	// Files returns every File hydrated as a member of this Package.
+	Files() []File
+
+	addFile(f File)
+}
+
+type pkg struct {
+	entityMeta
+	sourceCodeInfo
+
+	fd    *descriptor.FileDescriptorProto
+	files []File
+}
+
+func (p *pkg) ProtoName() Name { return Name(p.fd.GetPackage()) }
+
+func (p *pkg) FullyQualifiedName() string {
+	if name := p.fd.GetPackage(); name != "" {
+		return "." + name
+	}
+	return ""
+}
+
+func (p *pkg) Files() []File { return p.files }
+
+func (p *pkg) addFile(f File) { p.files = append(p.files, f) }
+
+var _ Package = (*pkg)(nil)
@@ -0,0 +1,119 @@
+package pgs
+
+import "sync"
+
+// astPlugins holds the globally registered ASTPlugins, invoked in
+// registration order as ProcessDescriptors hydrates the graph.
+var astPlugins []ASTPlugin
+
+// astPluginMu serializes every notifyHydrateX call below, so ASTPlugin
+// implementations only ever see one hydration event at a time even when
+// ProcessDescriptorsWithOptions hydrates multiple files concurrently.
+// Registration order is still honored within a single event; it is not
+// guaranteed across events from different files racing to notify, which a
+// concurrent ProcessDescriptorsWithOptions caller should expect.
+var astPluginMu sync.Mutex
+
+// ASTPlugin observes AST hydration as it happens, mirroring the
+// RegisterPlugin pattern from protoc-gen-go's generator. Each hook fires once
+// its Entity (and everything it depends on) has been fully linked, so
+// implementations can safely attach metadata via the Entity's SetMeta method
+// -- parsed custom options, HTTP rules, ORM annotations, etc. -- without
+// forking or wrapping the core Entity interfaces.
+//
+// Hook invocations are serialized (see astPluginMu), so an implementation
+// never needs its own locking to stay correct under
+// ProcessDescriptorsWithOptions' concurrent hydration. What it should not
+// assume under concurrent hydration is a single global order matching
+// req.GetProtoFile(): events for different files may interleave in whatever
+// order their shards happen to finish, though events within one file still
+// fire in the original hydration order.
+type ASTPlugin interface {
+	// AfterHydrateFile is called once a File and everything it declares has
+	// been fully linked.
+	AfterHydrateFile(File)
+
+	// AfterHydrateMessage is called once a Message, its fields, and its
+	// nested types have been fully linked.
+	AfterHydrateMessage(Message)
+
+	// AfterHydrateField is called once a Field's FieldType has been
+	// resolved.
+	AfterHydrateField(Field)
+
+	// AfterHydrateMethod is called once a Method's input and output types
+	// have been resolved.
+	AfterHydrateMethod(Method)
+}
+
+// RegisterASTPlugin adds p to the set of ASTPlugins invoked during
+// ProcessDescriptors. It is intended to be called from an init function of
+// the plugin's package, before ProcessDescriptors runs.
+func RegisterASTPlugin(p ASTPlugin) { astPlugins = append(astPlugins, p) }
+
+func (g *graph) notifyHydrateFile(f File) {
+	astPluginMu.Lock()
+	defer astPluginMu.Unlock()
+
+	for _, p := range astPlugins {
+		p.AfterHydrateFile(f)
+	}
+}
+
+func (g *graph) notifyHydrateMessage(m Message) {
+	astPluginMu.Lock()
+	defer astPluginMu.Unlock()
+
+	for _, p := range astPlugins {
+		p.AfterHydrateMessage(m)
+	}
+}
+
+func (g *graph) notifyHydrateField(f Field) {
+	astPluginMu.Lock()
+	defer astPluginMu.Unlock()
+
+	for _, p := range astPlugins {
+		p.AfterHydrateField(f)
+	}
+}
+
+func (g *graph) notifyHydrateMethod(m Method) {
+	astPluginMu.Lock()
+	defer astPluginMu.Unlock()
+
+	for _, p := range astPlugins {
+		p.AfterHydrateMethod(m)
+	}
+}
+
+// entityMeta is embedded into concrete Entity implementations to provide the
+// SetMeta/Meta side-channel, analogous to how sourceCodeInfo is mixed in to
+// supply SourceCodeInfo. It is safe for zero-value use and for concurrent
+// access, since a plugin's AfterHydrateX hook may run in a different shard's
+// goroutine than the one that later reads the same Entity's metadata.
+type entityMeta struct {
+	mu   sync.Mutex
+	meta map[interface{}]interface{}
+}
+
+// SetMeta attaches an arbitrary value to an Entity under key, overwriting any
+// existing value for that key. Intended for use by ASTPlugins.
+func (m *entityMeta) SetMeta(key, val interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.meta == nil {
+		m.meta = make(map[interface{}]interface{})
+	}
+	m.meta[key] = val
+}
+
+// Meta returns the value previously attached under key via SetMeta, if any.
+func (m *entityMeta) Meta(key interface{}) (interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	val, ok := m.meta[key]
+	return val, ok
+}
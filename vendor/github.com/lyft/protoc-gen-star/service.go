@@ -0,0 +1,37 @@
+package pgs
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// Service describes a single proto service declaration.
+type Service interface {
+	Entity
+
+	// File returns the File this Service is declared in.
+	File() File
+
+	// Methods returns this Service's RPC methods, in declaration order.
+	Methods() []Method
+
+	addMethod(m Method)
+}
+
+type service struct {
+	entityMeta
+	sourceCodeInfo
+
+	desc *descriptor.ServiceDescriptorProto
+	file File
+	mtds []Method
+}
+
+func (s *service) FullyQualifiedName() string {
+	return s.file.Package().FullyQualifiedName() + "." + s.desc.GetName()
+}
+
+func (s *service) File() File { return s.file }
+
+func (s *service) Methods() []Method { return s.mtds }
+
+func (s *service) addMethod(m Method) { s.mtds = append(s.mtds, m) }
+
+var _ Service = (*service)(nil)
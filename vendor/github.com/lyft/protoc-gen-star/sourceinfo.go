@@ -0,0 +1,38 @@
+package pgs
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// SourceCodeInfo exposes the comments and position associated with an
+// Entity, taken from its FileDescriptorProto's SourceCodeInfo.
+type SourceCodeInfo interface {
+	// Location returns the raw descriptor location this info was parsed
+	// from.
+	Location() *descriptor.SourceCodeInfo_Location
+}
+
+type sci struct {
+	desc *descriptor.SourceCodeInfo_Location
+}
+
+func (s sci) Location() *descriptor.SourceCodeInfo_Location { return s.desc }
+
+// sourceCodeInfo is embedded into concrete Entity implementations to provide
+// the addSourceCodeInfo/SourceCodeInfo side-channel, analogous to how
+// entityMeta is mixed in to supply SetMeta/Meta.
+type sourceCodeInfo struct {
+	info SourceCodeInfo
+}
+
+func (s *sourceCodeInfo) addSourceCodeInfo(info SourceCodeInfo) { s.info = info }
+
+func (s *sourceCodeInfo) SourceCodeInfo() SourceCodeInfo { return s.info }
+
+// FileDescriptorProto field numbers used to route top-level SourceCodeInfo
+// locations to the right Entity from hydrateSourceCodeInfo/File.childAtPath.
+const (
+	packagePath     = 2
+	messageTypePath = 4
+	enumTypePath    = 5
+	servicePath     = 6
+	syntaxPath      = 12
+)